@@ -0,0 +1,85 @@
+package filepathx
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// GlobN is like Glob, but bounds how many directory levels a "**" segment
+// is allowed to expand into. maxDepth < 0 means unlimited, i.e. the same
+// result as Glob; maxDepth is ignored when pattern has no "**".
+func GlobN(pattern string, maxDepth int) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+	return Globs(strings.Split(pattern, "**")).ExpandN(maxDepth)
+}
+
+// ExpandN is like Expand, but rather than recursively walking the entire
+// filesystem under each match, it expands every "**" into a bounded set of
+// wildcard suffixes ("", "*", "*/*", ..., up to maxDepth+1 levels) and
+// unions the filepath.Glob results for each one. A negative maxDepth means
+// unlimited, i.e. the same result as Expand. Otherwise, as with GlobN, a
+// pattern with no "**" (globs has at most one element) is only passed to
+// filepath.Glob, without expanding into any subtree.
+func (globs Globs) ExpandN(maxDepth int) ([]string, error) {
+	if maxDepth < 0 {
+		return globs.Expand()
+	}
+	if len(globs) <= 1 {
+		var pattern string
+		if len(globs) == 1 {
+			pattern = globs[0]
+		}
+		return filepath.Glob(pattern)
+	}
+
+	suffixes := boundedSuffixes(maxDepth)
+
+	var matches = []string{""} // accumulate here
+	for _, glob := range globs {
+		var hits []string
+		var hitMap = map[string]bool{}
+		for _, match := range matches {
+			paths, err := filepath.Glob(match + glob)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				for _, suffix := range suffixes {
+					subPaths, err := filepath.Glob(filepath.Join(path, suffix))
+					if err != nil {
+						return nil, err
+					}
+					for _, subPath := range subPaths {
+						// save deduped match from current iteration
+						if _, ok := hitMap[subPath]; !ok {
+							hits = append(hits, subPath)
+							hitMap[subPath] = true
+						}
+					}
+				}
+			}
+		}
+		matches = hits
+	}
+
+	// fix up return value for nil input
+	if globs == nil && len(matches) > 0 && matches[0] == "" {
+		matches = matches[1:]
+	}
+
+	return matches, nil
+}
+
+// boundedSuffixes returns the wildcard suffixes {"", "*", "*/*", ...,
+// strings.Repeat("*/", maxDepth)+"*"} used by ExpandN to cap how many
+// directory levels a "**" segment expands into.
+func boundedSuffixes(maxDepth int) []string {
+	suffixes := make([]string, maxDepth+2)
+	suffixes[0] = ""
+	for depth := 1; depth <= maxDepth+1; depth++ {
+		suffixes[depth] = strings.Repeat("*/", depth-1) + "*"
+	}
+	return suffixes
+}
@@ -0,0 +1,110 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func makeDepthTree(t *testing.T, dir string) {
+	t.Helper()
+	path := dir
+	for i := 0; i < 4; i++ {
+		path = filepath.Join(path, "lvl")
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, "file.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExpandNDepthBounds(t *testing.T) {
+	dir := t.TempDir()
+	makeDepthTree(t, dir)
+
+	for _, tc := range []struct {
+		maxDepth int
+		want     int // number of file.txt matches expected
+	}{
+		{maxDepth: 0, want: 1}, // lvl/file.txt only
+		{maxDepth: 1, want: 2}, // + lvl/lvl/file.txt
+		{maxDepth: 3, want: 4}, // all four levels
+	} {
+		got, err := GlobN(filepath.Join(dir, "**", "file.txt"), tc.maxDepth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tc.want {
+			t.Fatalf("GlobN(maxDepth=%d) = %v, want %d matches", tc.maxDepth, got, tc.want)
+		}
+	}
+}
+
+func TestExpandNNegativeMatchesExpand(t *testing.T) {
+	dir := t.TempDir()
+	makeDepthTree(t, dir)
+
+	pattern := filepath.Join(dir, "**", "file.txt")
+	want, err := Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := GlobN(pattern, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(want)
+	sort.Strings(got)
+	if len(got) != len(want) {
+		t.Fatalf("GlobN(maxDepth=-1) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("GlobN(maxDepth=-1) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGlobNNoDoubleStarPassesThrough(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "nested.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GlobN(filepath.Join(dir, "sub"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "sub")}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("GlobN() = %v, want %v (literal match, no recursion)", got, want)
+	}
+}
+
+func TestExpandNNoDoubleStarPassesThroughDirectly(t *testing.T) {
+	// Calling ExpandN directly on a single-element Globs bypasses GlobN's
+	// own "**"-free shortcut, and must still behave the same way.
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "nested.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Globs{filepath.Join(dir, "sub")}.ExpandN(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "sub")}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("ExpandN(0) = %v, want %v (literal match, no recursion)", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package filepathx
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// dirKey returns a stable identifier for path's underlying directory,
+// derived from its device and inode numbers. GlobWithOptions uses it to
+// detect symlink cycles when FollowSymlinks is set.
+func dirKey(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%d:%d", st.Dev, st.Ino), true
+}
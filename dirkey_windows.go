@@ -0,0 +1,16 @@
+//go:build windows
+
+package filepathx
+
+import "path/filepath"
+
+// dirKey falls back to the absolute path on platforms without POSIX
+// device/inode metadata. It still prevents revisiting the same directory
+// path, just not hardlinked or bind-mounted aliases of it.
+func dirKey(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	return abs, true
+}
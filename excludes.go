@@ -0,0 +1,156 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MatchDoubleStar reports whether name matches pattern, where "**"
+// matches zero or more path components. Unlike Glob it never touches the
+// filesystem, so it can test a path that may not exist on disk.
+func MatchDoubleStar(pattern, name string) (bool, error) {
+	patSegs := strings.Split(filepath.ToSlash(pattern), "/")
+	nameSegs := strings.Split(filepath.ToSlash(name), "/")
+	return matchSegments(patSegs, nameSegs)
+}
+
+// matchSegments reports whether patSegs matches nameSegs, where a "**"
+// segment matches zero or more name segments. It memoizes on (patIdx,
+// nameIdx) so that patterns with several consecutive "**" segments run in
+// O(len(patSegs)*len(nameSegs)) instead of backtracking exponentially when
+// the overall match fails.
+func matchSegments(patSegs, nameSegs []string) (bool, error) {
+	const unknown, isTrue, isFalse = 0, 1, 2
+	memo := make([][]int8, len(patSegs)+1)
+	for i := range memo {
+		memo[i] = make([]int8, len(nameSegs)+1)
+	}
+
+	var firstErr error
+	var match func(i, j int) bool
+	match = func(i, j int) bool {
+		if firstErr != nil {
+			return false
+		}
+		if v := memo[i][j]; v != unknown {
+			return v == isTrue
+		}
+
+		var result bool
+		switch {
+		case i == len(patSegs):
+			result = j == len(nameSegs)
+		case patSegs[i] == "**":
+			// "**" first tries to match zero name segments, then backs off
+			// one at a time.
+			result = match(i+1, j) || (j < len(nameSegs) && match(i, j+1))
+		case j < len(nameSegs):
+			ok, err := filepath.Match(patSegs[i], nameSegs[j])
+			if err != nil {
+				firstErr = err
+				return false
+			}
+			result = ok && match(i+1, j+1)
+		}
+
+		if result {
+			memo[i][j] = isTrue
+		} else {
+			memo[i][j] = isFalse
+		}
+		return result
+	}
+
+	ok := match(0, 0)
+	return ok, firstErr
+}
+
+// GlobWithExcludes is like Glob, but filters out any match whose path is
+// matched by one of excludes (see MatchDoubleStar). An exclude pattern
+// ending in "/**" excludes the whole matched directory's subtree.
+func GlobWithExcludes(pattern string, excludes []string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return filterExcludes(matches, excludes)
+	}
+	return Globs(strings.Split(pattern, "**")).ExpandWithExcludes(excludes)
+}
+
+// ExpandWithExcludes is like Expand, but filters the result through
+// excludes the same way GlobWithExcludes does.
+func (globs Globs) ExpandWithExcludes(excludes []string) ([]string, error) {
+	matches, err := globs.Expand()
+	if err != nil {
+		return nil, err
+	}
+	return filterExcludes(matches, excludes)
+}
+
+func filterExcludes(matches, excludes []string) ([]string, error) {
+	if len(excludes) == 0 {
+		return matches, nil
+	}
+
+	var kept []string
+	for _, match := range matches {
+		excluded, err := matchesAnyExclude(match, excludes)
+		if err != nil {
+			return nil, err
+		}
+		if !excluded {
+			kept = append(kept, match)
+		}
+	}
+	return kept, nil
+}
+
+func matchesAnyExclude(path string, excludes []string) (bool, error) {
+	for _, exclude := range excludes {
+		if prefix, ok := strings.CutSuffix(exclude, "/**"); ok {
+			excluded, err := excludedUnderDir(path, prefix)
+			if err != nil {
+				return false, err
+			}
+			if excluded {
+				return true, nil
+			}
+			continue
+		}
+		ok, err := MatchDoubleStar(exclude, path)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// excludedUnderDir reports whether path is, or lies anywhere underneath, a
+// directory matching dirPattern. It walks path's ancestors from the
+// deepest up, so the is-a-directory check lands on the excluded root
+// itself rather than on every descendant file.
+func excludedUnderDir(path, dirPattern string) (bool, error) {
+	segs := strings.Split(filepath.ToSlash(path), "/")
+	for i := len(segs); i >= 1; i-- {
+		ancestor := strings.Join(segs[:i], "/")
+		ok, err := MatchDoubleStar(dirPattern, ancestor)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+		info, err := os.Stat(filepath.FromSlash(ancestor))
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,80 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGlobWithExcludesTrailingDoubleStarPrunesSubtree(t *testing.T) {
+	dir := t.TempDir()
+	dirA := filepath.Join(dir, "testdir", "sub", "dirA")
+	dirB := filepath.Join(dir, "testdir", "sub", "dirB")
+	if err := os.MkdirAll(dirA, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dirB, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirA, "file1.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirB, "file2.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GlobWithExcludes(
+		filepath.Join(dir, "testdir", "**", "*"),
+		[]string{filepath.Join(dir, "testdir", "sub", "dirA") + "/**"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	for _, excluded := range []string{dirA, filepath.Join(dirA, "file1.txt")} {
+		for _, g := range got {
+			if g == excluded {
+				t.Fatalf("GlobWithExcludes() kept %q, want it pruned along with its parent dir; got %v", excluded, got)
+			}
+		}
+	}
+
+	foundFile2 := false
+	for _, g := range got {
+		if g == filepath.Join(dirB, "file2.txt") {
+			foundFile2 = true
+		}
+	}
+	if !foundFile2 {
+		t.Fatalf("GlobWithExcludes() = %v, want it to still contain dirB's file2.txt", got)
+	}
+}
+
+func TestMatchDoubleStarManyDoubleStarsDoesNotBacktrackExponentially(t *testing.T) {
+	pattern := strings.Repeat("**/", 20) + "nomatch"
+	name := strings.Repeat("a/", 20) + "b"
+
+	done := make(chan struct{})
+	var ok bool
+	var err error
+	go func() {
+		ok, err = MatchDoubleStar(pattern, name)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("MatchDoubleStar did not return within 3s on a pattern with many consecutive ** segments")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatalf("MatchDoubleStar(%q, %q) = true, want false", pattern, name)
+	}
+}
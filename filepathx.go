@@ -4,6 +4,7 @@
 package filepathx
 
 import (
+	"context"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -35,8 +36,19 @@ func GlobFS(f fs.FS, pattern string) ([]string, error) {
 
 // Expand finds matches for the provided Globs.
 func (globs Globs) Expand() ([]string, error) {
+	return globs.expandContext(context.Background())
+}
+
+// expandContext is the shared implementation behind Expand and ExpandIter.
+// It walks the same way Expand always has, except that filepath.Walk is
+// given the chance to bail out early via filepath.SkipDir once ctx is
+// cancelled.
+func (globs Globs) expandContext(ctx context.Context) ([]string, error) {
 	var matches = []string{""} // accumulate here
 	for _, glob := range globs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var hits []string
 		var hitMap = map[string]bool{}
 		for _, match := range matches {
@@ -49,6 +61,9 @@ func (globs Globs) Expand() ([]string, error) {
 					if err != nil {
 						return err
 					}
+					if ctx.Err() != nil {
+						return filepath.SkipDir
+					}
 					// save deduped match from current iteration
 					if _, ok := hitMap[path]; !ok {
 						hits = append(hits, path)
@@ -64,6 +79,10 @@ func (globs Globs) Expand() ([]string, error) {
 		matches = hits
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// fix up return value for nil input
 	if globs == nil && len(matches) > 0 && matches[0] == "" {
 		matches = matches[1:]
@@ -73,8 +92,17 @@ func (globs Globs) Expand() ([]string, error) {
 }
 
 func (globs Globs) ExpandFS(f fs.FS) ([]string, error) {
+	return globs.expandFSContext(context.Background(), f)
+}
+
+// expandFSContext is the shared implementation behind ExpandFS and
+// ExpandIterFS; see expandContext for why it takes a context.
+func (globs Globs) expandFSContext(ctx context.Context, f fs.FS) ([]string, error) {
 	var matches = []string{""} // accumulate here
 	for _, glob := range globs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var hits []string
 		var hitMap = map[string]bool{}
 		for _, match := range matches {
@@ -95,6 +123,9 @@ func (globs Globs) ExpandFS(f fs.FS) ([]string, error) {
 					if err != nil {
 						return err
 					}
+					if ctx.Err() != nil {
+						return filepath.SkipDir
+					}
 					// save deduped match from current iteration
 					if _, ok := hitMap[path]; !ok {
 						hits = append(hits, path)
@@ -110,6 +141,10 @@ func (globs Globs) ExpandFS(f fs.FS) ([]string, error) {
 		matches = hits
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// fix up return value for nil input
 	if globs == nil && len(matches) > 0 && matches[0] == "" {
 		matches = matches[1:]
@@ -0,0 +1,210 @@
+package filepathx
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Match is a single result yielded by GlobIter/GlobIterFS. When Err is nil,
+// exactly one of FileInfo (from GlobIter) or DirEntry (from GlobIterFS) is
+// populated, mirroring the two filesystem abstractions Glob/GlobFS already
+// support. Err is set if stat-ing Path or walking from it failed, in which
+// case FileInfo and DirEntry may be nil; it is also set, with an empty
+// Path, when the final pattern segment is malformed.
+type Match struct {
+	Path     string
+	FileInfo os.FileInfo
+	DirEntry fs.DirEntry
+	Err      error
+}
+
+// GlobIter is like Glob, but streams matches over the returned channel as
+// they're discovered instead of materializing the full result slice. The
+// channel is closed once the walk completes or ctx is cancelled. A caller
+// that stops ranging over the channel before it's drained MUST cancel ctx
+// (e.g. via context.WithCancel and a deferred cancel) — otherwise the
+// producer goroutine blocks forever trying to send the next match and
+// leaks.
+func GlobIter(ctx context.Context, pattern string) (<-chan Match, error) {
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return globIterChan(ctx, matches), nil
+	}
+	return Globs(strings.Split(pattern, "**")).ExpandIter(ctx)
+}
+
+// GlobIterFS is the fs.FS counterpart of GlobIter; see GlobIter's doc
+// comment for the ctx-cancellation requirement when abandoning the channel
+// early.
+func GlobIterFS(ctx context.Context, f fs.FS, pattern string) (<-chan Match, error) {
+	if !strings.Contains(pattern, "**") {
+		matches, err := fs.Glob(f, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return globIterFSChan(ctx, f, matches), nil
+	}
+	return Globs(strings.Split(pattern, "**")).ExpandIterFS(ctx, f)
+}
+
+// ExpandIter is the streaming counterpart of Expand. Every segment but the
+// last is resolved eagerly, since each needs the full previous segment's
+// matches to build the next filepath.Glob pattern; the last segment's
+// filepath.Walk pushes onto the channel as it discovers entries instead of
+// accumulating them first.
+func (globs Globs) ExpandIter(ctx context.Context) (<-chan Match, error) {
+	prefixGlobs, last := globs.splitLast()
+	prefixes, err := prefixGlobs.expandContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Match)
+	go func() {
+		defer close(ch)
+		hitMap := map[string]bool{}
+		for _, prefix := range prefixes {
+			paths, err := filepath.Glob(prefix + last)
+			if err != nil {
+				sendMatch(ctx, ch, Match{Err: err})
+				continue
+			}
+			for _, path := range paths {
+				walkErr := filepath.Walk(path, func(walkPath string, info os.FileInfo, err error) error {
+					if err != nil {
+						return err
+					}
+					if ctx.Err() != nil {
+						return filepath.SkipDir
+					}
+					if hitMap[walkPath] {
+						return nil
+					}
+					hitMap[walkPath] = true
+					if !sendMatch(ctx, ch, Match{Path: walkPath, FileInfo: info}) {
+						return filepath.SkipDir
+					}
+					return nil
+				})
+				if walkErr != nil && ctx.Err() == nil {
+					sendMatch(ctx, ch, Match{Path: path, Err: walkErr})
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// ExpandIterFS is the streaming counterpart of ExpandFS; see ExpandIter for
+// why only the last segment streams.
+func (globs Globs) ExpandIterFS(ctx context.Context, f fs.FS) (<-chan Match, error) {
+	prefixGlobs, last := globs.splitLast()
+	prefixes, err := prefixGlobs.expandFSContext(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Match)
+	go func() {
+		defer close(ch)
+		hitMap := map[string]bool{}
+		for _, prefix := range prefixes {
+			pattern := prefix + last
+			// strip ending /
+			if strings.HasSuffix(pattern, "/") {
+				pattern = pattern[:len(pattern)-1]
+			}
+			if pattern == "" {
+				pattern = "*"
+			}
+			paths, err := fs.Glob(f, pattern)
+			if err != nil {
+				sendMatch(ctx, ch, Match{Err: err})
+				continue
+			}
+			for _, path := range paths {
+				walkErr := fs.WalkDir(f, path, func(walkPath string, d fs.DirEntry, err error) error {
+					if err != nil {
+						return err
+					}
+					if ctx.Err() != nil {
+						return filepath.SkipDir
+					}
+					if hitMap[walkPath] {
+						return nil
+					}
+					hitMap[walkPath] = true
+					if !sendMatch(ctx, ch, Match{Path: walkPath, DirEntry: d}) {
+						return filepath.SkipDir
+					}
+					return nil
+				})
+				if walkErr != nil && ctx.Err() == nil {
+					sendMatch(ctx, ch, Match{Path: path, Err: walkErr})
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// splitLast splits globs into every segment but the last (fed through
+// expandContext/expandFSContext to seed the final filepath.Glob/fs.Glob
+// pattern) and the last segment itself (walked in a streaming fashion).
+func (globs Globs) splitLast() (Globs, string) {
+	if len(globs) == 0 {
+		return nil, ""
+	}
+	return globs[:len(globs)-1], globs[len(globs)-1]
+}
+
+func globIterChan(ctx context.Context, paths []string) <-chan Match {
+	ch := make(chan Match)
+	go func() {
+		defer close(ch)
+		for _, path := range paths {
+			info, err := os.Lstat(path)
+			if !sendMatch(ctx, ch, Match{Path: path, FileInfo: info, Err: err}) {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func globIterFSChan(ctx context.Context, f fs.FS, paths []string) <-chan Match {
+	ch := make(chan Match)
+	go func() {
+		defer close(ch)
+		for _, path := range paths {
+			info, err := fs.Stat(f, path)
+			var entry fs.DirEntry
+			if err == nil {
+				entry = fs.FileInfoToDirEntry(info)
+			}
+			if !sendMatch(ctx, ch, Match{Path: path, DirEntry: entry, Err: err}) {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// sendMatch delivers m on ch, returning false if ctx is cancelled first.
+// The send still blocks until one of those happens: a caller that stops
+// reading from ch without cancelling ctx leaves the producer goroutine
+// parked here.
+func sendMatch(ctx context.Context, ch chan<- Match, m Match) bool {
+	select {
+	case ch <- m:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
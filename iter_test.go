@@ -0,0 +1,147 @@
+package filepathx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestExpandIterStopsEarly(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 50; i++ {
+		sub := filepath.Join(dir, "sub", string(rune('a'+i%26)), "deep")
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, "file.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := GlobIter(ctx, filepath.Join(dir, "**", "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, ok := <-ch
+	if !ok {
+		t.Fatal("expected at least one match before cancelling")
+	}
+	if m.Err != nil {
+		t.Fatal(m.Err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			// a second in-flight send racing the cancel is fine, drain once more
+			select {
+			case <-ch:
+			case <-time.After(time.Second):
+				t.Fatal("channel did not close promptly after ctx cancellation")
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel did not close promptly after ctx cancellation")
+	}
+}
+
+// TestGlobIterRequiresCancelToAvoidLeak documents the contract spelled out
+// in GlobIter's doc comment: a consumer that stops ranging over the
+// channel without cancelling ctx leaves the producer goroutine permanently
+// blocked on the send.
+func TestGlobIterRequiresCancelToAvoidLeak(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 20; i++ {
+		sub := filepath.Join(dir, "sub", string(rune('a'+i)))
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, "file.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := GlobIter(ctx, filepath.Join(dir, "**", "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-ch; !ok {
+		t.Fatal("expected at least one match")
+	}
+
+	// Stop reading without cancelling: per the documented contract, the
+	// producer goroutine stays parked on its next channel send.
+	deadline := time.Now().Add(time.Second)
+	leaked := false
+	for time.Now().Before(deadline) {
+		if runtime.NumGoroutine() > before {
+			leaked = true
+			break
+		}
+		runtime.Gosched()
+	}
+	if !leaked {
+		t.Fatalf("expected the producer goroutine to still be blocked without a cancel, NumGoroutine() never rose above %d", before)
+	}
+
+	// Cancelling unblocks it, as documented, and avoids actually leaking
+	// the goroutine past the end of this test.
+	cancel()
+	for range ch {
+	}
+}
+
+func TestExpandIterMatchesExpand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "b", "file.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pattern := filepath.Join(dir, "**", "file.txt")
+	want, err := Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := GlobIter(context.Background(), pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for m := range ch {
+		if m.Err != nil {
+			t.Fatal(m.Err)
+		}
+		got = append(got, m.Path)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("GlobIter returned %v, want %v", got, want)
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("GlobIter missing %q, got %v", w, got)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Matcher abstracts the pattern-matching engine Globs uses while expanding
+// a pattern. Split breaks a pattern into the pieces Expand walks over
+// (the default does strings.Split(pattern, "**")); Match tests a single
+// path component's pattern against a candidate name.
+type Matcher interface {
+	Match(pattern, name string) (bool, error)
+	Split(pattern string) []string
+}
+
+// defaultMatcher preserves filepathx's original "**"-delimited semantics,
+// backed by path/filepath.Match.
+type defaultMatcher struct{}
+
+func (defaultMatcher) Match(pattern, name string) (bool, error) {
+	return filepath.Match(pattern, name)
+}
+
+func (defaultMatcher) Split(pattern string) []string {
+	return strings.Split(pattern, "**")
+}
+
+// DefaultMatcher splits on "**" and matches with path/filepath.Match, the
+// same semantics Glob, GlobFS and Globs.Expand have; those functions call
+// filepath.Glob/fs.Glob directly rather than going through a Matcher.
+var DefaultMatcher Matcher = defaultMatcher{}
+
+// WithMatcher pairs a Globs with the Matcher that should be used to
+// evaluate it, so callers can opt into richer pattern syntax (see
+// ExtendedMatcher) without changing Glob's default behavior.
+type WithMatcher struct {
+	Globs
+	Matcher Matcher
+}
+
+// NewGlobs splits pattern using m.Split and pairs the result with m, ready
+// to Expand.
+func NewGlobs(pattern string, m Matcher) WithMatcher {
+	return WithMatcher{Globs: Globs(m.Split(pattern)), Matcher: m}
+}
+
+// Expand finds matches for g.Globs the same way Globs.Expand does, except
+// that filesystem entries are matched against each pattern component using
+// g.Matcher instead of filepath.Glob. As with Glob, a pattern with no "**"
+// (g.Globs has at most one element) is returned as-is, without walking
+// into matched directories.
+func (g WithMatcher) Expand() ([]string, error) {
+	if len(g.Globs) <= 1 {
+		var pattern string
+		if len(g.Globs) == 1 {
+			pattern = g.Globs[0]
+		}
+		return globWithMatcher(g.Matcher, pattern)
+	}
+
+	var matches = []string{""} // accumulate here
+	for _, glob := range g.Globs {
+		var hits []string
+		var hitMap = map[string]bool{}
+		for _, match := range matches {
+			paths, err := globWithMatcher(g.Matcher, match+glob)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				err = filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+					if err != nil {
+						return err
+					}
+					// save deduped match from current iteration
+					if _, ok := hitMap[path]; !ok {
+						hits = append(hits, path)
+						hitMap[path] = true
+					}
+					return nil
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		matches = hits
+	}
+
+	return matches, nil
+}
+
+// globWithMatcher lists the filesystem entries matching pattern the way
+// filepath.Glob does, except each path component is tested against m
+// instead of filepath.Match, so Matcher implementations can support syntax
+// filepath.Match doesn't (character classes, braces, ...).
+func globWithMatcher(m Matcher, pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(pattern)
+	segments := strings.Split(pattern, "/")
+
+	roots := []string{"."}
+	if strings.HasPrefix(pattern, "/") {
+		roots = []string{"/"}
+	}
+
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		var next []string
+		for _, root := range roots {
+			dirMatches, err := matchDirEntries(m, root, seg)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, dirMatches...)
+		}
+		roots = next
+	}
+
+	if !strings.HasPrefix(pattern, "/") {
+		for i, root := range roots {
+			roots[i] = strings.TrimPrefix(root, "./")
+		}
+	}
+	return roots, nil
+}
+
+// matchDirEntries returns the entries of dir whose name matches seg
+// according to m, joined back onto dir.
+func matchDirEntries(m Matcher, dir, seg string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+	var matches []string
+	for _, entry := range entries {
+		ok, err := m.Match(seg, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// ExtendedMatcher wraps another Matcher (DefaultMatcher if Inner is nil)
+// and delegates Match and Split to it unchanged. Brace expansion, e.g.
+// "src/{a,b}/**/*.go", is done by GlobExtended, which pre-expands the
+// braces with ExpandBraces and unions the results; ExtendedMatcher used
+// directly with NewGlobs does not expand braces.
+type ExtendedMatcher struct {
+	Inner Matcher
+}
+
+func (m ExtendedMatcher) inner() Matcher {
+	if m.Inner != nil {
+		return m.Inner
+	}
+	return DefaultMatcher
+}
+
+func (m ExtendedMatcher) Match(pattern, name string) (bool, error) {
+	return m.inner().Match(pattern, name)
+}
+
+func (m ExtendedMatcher) Split(pattern string) []string {
+	return m.inner().Split(pattern)
+}
+
+// ExpandBraces expands every "{a,b,c}" brace group in pattern into the
+// cartesian product of its alternatives, e.g. "src/{a,b}/*.go" becomes
+// []string{"src/a/*.go", "src/b/*.go"}. A pattern with no brace group is
+// returned unchanged as a single-element slice.
+func ExpandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start == -1 {
+		return []string{pattern}
+	}
+	end := strings.IndexByte(pattern[start:], '}')
+	if end == -1 {
+		return []string{pattern}
+	}
+	end += start
+
+	prefix, suffix := pattern[:start], pattern[end+1:]
+	alternatives := strings.Split(pattern[start+1:end], ",")
+
+	var expanded []string
+	for _, alt := range alternatives {
+		expanded = append(expanded, ExpandBraces(prefix+alt+suffix)...)
+	}
+	return expanded
+}
+
+// GlobExtended is like Glob, but pattern may also use brace expansion,
+// e.g. "src/{a,b}/**/*.go".
+func GlobExtended(pattern string) ([]string, error) {
+	var matches []string
+	hitMap := map[string]bool{}
+	for _, alt := range ExpandBraces(pattern) {
+		paths, err := NewGlobs(alt, ExtendedMatcher{}).Expand()
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			if _, ok := hitMap[path]; !ok {
+				matches = append(matches, path)
+				hitMap[path] = true
+			}
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,40 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestGlobExtendedNoDoubleStarDoesNotRecurse(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"x", "y"} {
+		nested := filepath.Join(dir, "out", sub, "inner")
+		if err := os.MkdirAll(nested, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "out", sub, "file.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(nested, "deep.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := GlobExtended(filepath.Join(dir, "out", "{x,y}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	want := []string{filepath.Join(dir, "out", "x"), filepath.Join(dir, "out", "y")}
+	if len(got) != len(want) {
+		t.Fatalf("GlobExtended() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("GlobExtended() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options controls optional matching/walking behavior for GlobWithOptions.
+type Options struct {
+	// CaseInsensitive matches pattern literals against path components
+	// case-insensitively.
+	CaseInsensitive bool
+	// FollowSymlinks makes "**" recursion descend into symlinked
+	// directories instead of treating them as leaves.
+	FollowSymlinks bool
+	// IncludeHidden includes dotfiles/dotdirs discovered during "**"
+	// recursion. Defaults to false, matching bash/zsh's globstar.
+	IncludeHidden bool
+}
+
+// GlobWithOptions is like Glob, but lets the caller opt into case-
+// insensitive matching, symlink-following, and hidden-entry inclusion.
+func GlobWithOptions(pattern string, opts Options) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return globSegment(pattern, opts)
+	}
+	return Globs(strings.Split(pattern, "**")).ExpandWithOptions(opts)
+}
+
+// ExpandWithOptions is like Expand, but walks according to opts instead of
+// always using filepath.Glob/filepath.Walk with their default behavior.
+func (globs Globs) ExpandWithOptions(opts Options) ([]string, error) {
+	var matches = []string{""} // accumulate here
+	for _, glob := range globs {
+		var hits []string
+		var hitMap = map[string]bool{}
+		for _, match := range matches {
+			paths, err := globSegment(match+glob, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				err = walkWithOptions(path, opts, func(p string) {
+					// save deduped match from current iteration
+					if _, ok := hitMap[p]; !ok {
+						hits = append(hits, p)
+						hitMap[p] = true
+					}
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		matches = hits
+	}
+
+	// fix up return value for nil input
+	if globs == nil && len(matches) > 0 && matches[0] == "" {
+		matches = matches[1:]
+	}
+
+	return matches, nil
+}
+
+// globSegment matches a single (non-"**") glob segment against the
+// filesystem, folding case per opts.CaseInsensitive.
+func globSegment(pattern string, opts Options) ([]string, error) {
+	if !opts.CaseInsensitive {
+		return filepath.Glob(pattern)
+	}
+	return globWithMatcher(caseInsensitiveMatcher{}, pattern)
+}
+
+type caseInsensitiveMatcher struct{}
+
+func (caseInsensitiveMatcher) Match(pattern, name string) (bool, error) {
+	return filepath.Match(strings.ToLower(pattern), strings.ToLower(name))
+}
+
+func (caseInsensitiveMatcher) Split(pattern string) []string {
+	return strings.Split(pattern, "**")
+}
+
+// walkWithOptions walks the tree rooted at root, calling visit for every
+// entry, honoring opts.IncludeHidden and opts.FollowSymlinks. root itself
+// is always visited regardless of opts.IncludeHidden, since it is a match
+// of the pattern's own segment rather than an entry found by the "**"
+// recursion; hidden filtering only applies to entries discovered below it.
+// A hidden root matched by a wildcard in the pattern (e.g. "*" matching
+// ".git") is therefore still visited.
+func walkWithOptions(root string, opts Options, visit func(string)) error {
+	return walkWithOptionsRec(root, opts, true, map[string]bool{}, visit)
+}
+
+func walkWithOptionsRec(path string, opts Options, isRoot bool, visited map[string]bool, visit func(string)) error {
+	lstat, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	if !isRoot && !opts.IncludeHidden && isHiddenEntry(path) {
+		return nil
+	}
+
+	info := lstat
+	if lstat.Mode()&os.ModeSymlink != 0 {
+		if !opts.FollowSymlinks {
+			visit(path)
+			return nil
+		}
+		info, err = os.Stat(path)
+		if err != nil {
+			// broken symlink: report it like filepath.Walk would, but
+			// don't try to descend into it.
+			visit(path)
+			return nil
+		}
+	}
+
+	if info.IsDir() {
+		if key, ok := dirKey(path); ok {
+			if visited[key] {
+				return nil
+			}
+			visited[key] = true
+		}
+	}
+
+	visit(path)
+
+	if !info.IsDir() {
+		return nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := walkWithOptionsRec(filepath.Join(path, entry.Name()), opts, false, visited, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isHiddenEntry reports whether path's base name is a dotfile/dotdir,
+// matching zsh/bash globstar's default of skipping hidden entries.
+func isHiddenEntry(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasPrefix(base, ".") && base != "." && base != ".."
+}
@@ -0,0 +1,56 @@
+package filepathx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGlobWithOptionsExplicitHiddenRoot(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, ".config", "sub")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "file.json"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GlobWithOptions(filepath.Join(dir, ".config", "**", "*.json"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(sub, "file.json")
+	found := false
+	for _, g := range got {
+		if g == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("GlobWithOptions() = %v, want it to contain %q even with IncludeHidden unset, since .config was named explicitly", got, want)
+	}
+}
+
+func TestGlobWithOptionsHidesWildcardDiscoveredDotdirs(t *testing.T) {
+	dir := t.TempDir()
+	hidden := filepath.Join(dir, "sub", ".git")
+	if err := os.MkdirAll(hidden, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hidden, "config.json"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GlobWithOptions(filepath.Join(dir, "**", "*.json"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range got {
+		if g == filepath.Join(hidden, "config.json") {
+			t.Fatalf("GlobWithOptions() = %v, want .git discovered via ** to stay hidden by default", got)
+		}
+	}
+}